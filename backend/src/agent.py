@@ -27,6 +27,7 @@ from src.prompts import (
 )
 from src.cache_monitor import CacheMonitor
 from src.analysis_cache import AnalysisCache, compute_file_hash, AnalysisResult
+from src.utils.source_input import normalize_source_text
 
 logger = logging.getLogger(__name__)
 
@@ -175,6 +176,9 @@ class IrisAgent:
         Raises:
             IrisError: On LLM API failures or invalid responses.
         """
+        # Strip BOM and CRLF so Windows clients hash and prompt identically
+        source_code = normalize_source_text(source_code)
+
         # Early return for empty files (no LLM call needed)
         if not source_code.strip():
             logger.info(f"Empty file detected: {filename}")
@@ -321,6 +325,7 @@ class IrisAgent:
                             block.model_dump()
                             for block in content.responsibility_blocks
                         ],
+                        language=language,
                     )
                     await self.analysis_cache.set(file_hash, result_obj)
                 except Exception as e:
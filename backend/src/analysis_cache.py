@@ -7,7 +7,7 @@ Implements hybrid memory (LRU) + disk cache with content-addressable storage.
 import hashlib
 import json
 import logging
-from collections import OrderedDict
+from collections import Counter, OrderedDict
 from dataclasses import dataclass, asdict
 from datetime import datetime, timedelta
 from pathlib import Path
@@ -15,6 +15,9 @@ from typing import Optional, Dict, Any, List
 
 logger = logging.getLogger(__name__)
 
+# Stats/eviction bucket for entries cached before language was recorded
+UNKNOWN_LANGUAGE = "unknown"
+
 
 def compute_file_hash(content: str) -> str:
     """
@@ -39,12 +42,14 @@ class AnalysisResult:
 
     file_intent: str
     responsibility_blocks: List[Dict[str, Any]]
+    language: Optional[str] = None
 
     def to_dict(self) -> Dict[str, Any]:
         """Convert to dictionary for JSON serialization."""
         return {
             "file_intent": self.file_intent,
             "responsibility_blocks": self.responsibility_blocks,
+            "language": self.language,
         }
 
     @classmethod
@@ -53,6 +58,7 @@ class AnalysisResult:
         return cls(
             file_intent=data["file_intent"],
             responsibility_blocks=data["responsibility_blocks"],
+            language=data.get("language"),
         )
 
 
@@ -71,6 +77,9 @@ class AnalysisCache:
     - Memory: OrderedDict for O(1) LRU access
     - Disk: JSON files named by content hash
     - TTL: 30 days for disk entries
+    - Multi-worker: disk is shared, so memory hits for disk-backed entries
+      are revalidated against the disk file to honor evictions made by
+      other Gunicorn workers
     """
 
     def __init__(
@@ -97,6 +106,9 @@ class AnalysisCache:
         # Memory cache (LRU via OrderedDict)
         self._memory_cache: OrderedDict[str, AnalysisResult] = OrderedDict()
 
+        # Memory keys known to have a disk file (write succeeded or read from disk)
+        self._disk_backed: set[str] = set()
+
         # Ensure disk cache directory exists
         self.disk_cache_dir.mkdir(parents=True, exist_ok=True)
 
@@ -122,6 +134,17 @@ class AnalysisCache:
         Returns:
             Analysis result as dictionary, or None if not cached
         """
+        # Drop memory hits whose disk file is gone: for entries that were
+        # written to disk, a missing file means another worker evicted or
+        # flushed them. Entries whose disk write failed stay memory-only.
+        if (
+            file_hash in self._disk_backed
+            and not self._get_cache_file_path(file_hash).exists()
+        ):
+            self._memory_cache.pop(file_hash, None)
+            self._disk_backed.discard(file_hash)
+            logger.debug(f"Dropped {file_hash[:8]}... (evicted on disk)")
+
         # Check memory cache
         if file_hash in self._memory_cache:
             # Move to end (most recently used)
@@ -139,6 +162,7 @@ class AnalysisCache:
         if disk_result is not None:
             # Promote to memory cache
             self._add_to_memory(file_hash, disk_result)
+            self._disk_backed.add(file_hash)
 
             if self.cache_monitor:
                 self.cache_monitor.record_local_cache_hit(file_size_bytes)
@@ -167,7 +191,10 @@ class AnalysisCache:
         self._add_to_memory(file_hash, result)
 
         # Store in disk cache
-        self._write_to_disk(file_hash, result)
+        if self._write_to_disk(file_hash, result):
+            self._disk_backed.add(file_hash)
+        else:
+            self._disk_backed.discard(file_hash)
 
         logger.debug(f"Cached analysis for {file_hash[:8]}...")
 
@@ -184,6 +211,7 @@ class AnalysisCache:
         if len(self._memory_cache) > self.max_memory_entries:
             oldest_key = next(iter(self._memory_cache))
             self._memory_cache.pop(oldest_key)
+            self._disk_backed.discard(oldest_key)
             logger.debug(f"Evicted {oldest_key[:8]}... from memory cache (LRU)")
 
     def _read_from_disk(self, file_hash: str) -> Optional[AnalysisResult]:
@@ -219,8 +247,8 @@ class AnalysisCache:
                 pass
             return None
 
-    def _write_to_disk(self, file_hash: str, result: AnalysisResult) -> None:
-        """Write result to disk cache."""
+    def _write_to_disk(self, file_hash: str, result: AnalysisResult) -> bool:
+        """Write result to disk cache. Returns True if the file was written."""
         cache_file = self._get_cache_file_path(file_hash)
 
         try:
@@ -232,6 +260,9 @@ class AnalysisCache:
 
         except Exception as e:
             logger.error(f"Failed to write cache file {cache_file}: {e}")
+            return False
+
+        return True
 
     def _get_cache_file_path(self, file_hash: str) -> Path:
         """Get path to cache file for given hash."""
@@ -264,30 +295,116 @@ class AnalysisCache:
         if removed_count > 0:
             logger.info(f"Cleaned up {removed_count} expired cache entries")
 
-    def get_cache_stats(self) -> Dict[str, Any]:
+    def _iter_disk_entries(self):
+        """Yield (file_hash, language) for every readable disk cache entry."""
+        if not self.disk_cache_dir.exists():
+            return
+
+        for cache_file in self.disk_cache_dir.glob("*.json"):
+            try:
+                with open(cache_file, "r", encoding="utf-8") as f:
+                    data = json.load(f)
+            except Exception as e:
+                logger.error(f"Failed to read cache file {cache_file}: {e}")
+                continue
+            yield cache_file.stem, data.get("language")
+
+    def get_cache_stats(self, top_keys: int = 5) -> Dict[str, Any]:
         """
         Get cache statistics.
 
+        Args:
+            top_keys: Number of most recently used keys to list per language
+
         Returns:
-            Dictionary with memory and disk cache stats
+            Dictionary with memory and disk cache stats, broken down by language
         """
-        disk_entries = (
-            len(list(self.disk_cache_dir.glob("*.json")))
-            if self.disk_cache_dir.exists()
-            else 0
+        disk_entries = list(self._iter_disk_entries())
+
+        # Memory keys are in LRU order, so most recently used come last
+        keys_by_language: Dict[str, List[str]] = {}
+        for file_hash, result in reversed(self._memory_cache.items()):
+            language = result.language or UNKNOWN_LANGUAGE
+            keys_by_language.setdefault(language, [])
+            if len(keys_by_language[language]) < top_keys:
+                keys_by_language[language].append(file_hash)
+
+        disk_counts = Counter(
+            language or UNKNOWN_LANGUAGE for _, language in disk_entries
         )
 
         return {
             "memory_entries": len(self._memory_cache),
             "memory_max": self.max_memory_entries,
-            "disk_entries": disk_entries,
+            "disk_entries": len(disk_entries),
             "disk_ttl_days": self.disk_ttl_days,
+            "languages": {
+                language: {
+                    "disk_entries": disk_counts.get(language, 0),
+                    "top_keys": keys_by_language.get(language, []),
+                }
+                for language in sorted(set(disk_counts) | set(keys_by_language))
+            },
+        }
+
+    def evict(self, file_hash: str) -> bool:
+        """
+        Remove a single entry from both memory and disk caches.
+
+        Args:
+            file_hash: SHA-256 hash of file content
+
+        Returns:
+            True if an entry was found and removed
+        """
+        removed = self._memory_cache.pop(file_hash, None) is not None
+        self._disk_backed.discard(file_hash)
+
+        cache_file = self._get_cache_file_path(file_hash)
+        if cache_file.exists():
+            try:
+                cache_file.unlink()
+                removed = True
+            except Exception as e:
+                logger.error(f"Failed to delete cache file {cache_file}: {e}")
+
+        if removed:
+            logger.info(f"Evicted {file_hash[:8]}... from cache")
+        return removed
+
+    def evict_language(self, language: str) -> int:
+        """
+        Remove all entries analyzed as the given language.
+
+        Entries cached before language was recorded are grouped under
+        UNKNOWN_LANGUAGE, matching get_cache_stats().
+
+        Args:
+            language: Language identifier (e.g., 'python', 'unknown')
+
+        Returns:
+            Number of distinct entries removed
+        """
+        file_hashes = {
+            file_hash
+            for file_hash, result in self._memory_cache.items()
+            if (result.language or UNKNOWN_LANGUAGE) == language
         }
+        file_hashes.update(
+            file_hash
+            for file_hash, entry_language in self._iter_disk_entries()
+            if (entry_language or UNKNOWN_LANGUAGE) == language
+        )
+
+        removed_count = sum(1 for file_hash in file_hashes if self.evict(file_hash))
+        logger.info(f"Evicted {removed_count} {language} entries from cache")
+        return removed_count
 
     def clear(self) -> None:
         """Clear both memory and disk caches."""
         # Clear memory
         self._memory_cache.clear()
+        self._disk_backed.clear()
 
         # Clear disk
         if self.disk_cache_dir.exists():
@@ -184,9 +184,15 @@ def build_single_shot_user_prompt(
     Returns:
         Formatted user prompt string with structured input tags.
     """
-    # Add line numbers to each line
+    # Add line numbers to each line. Split on LF only (source is normalized
+    # upstream): splitlines() also breaks on \f, \v, \x85, \u2028, etc.,
+    # which editors do not, and would shift the returned ranges.
+    lines = source_code.split("\n")
+    if lines[-1] == "":
+        lines.pop()
+
     numbered_lines = []
-    for i, line in enumerate(source_code.splitlines(), start=1):
+    for i, line in enumerate(lines, start=1):
         numbered_lines.append(f"{i:4d}|{line}")
     numbered_source = "\n".join(numbered_lines)
 
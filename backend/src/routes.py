@@ -18,6 +18,7 @@ logger = logging.getLogger(__name__)
 
 from src.config import SUPPORTED_LANGUAGES, SINGLE_SHOT_MODEL
 from src.agent import IrisAgent, IrisError
+from src.utils.source_input import decode_source_base64, looks_binary
 from src.utils.analytics_emf import (
     emit_emf_event,
     build_analysis_requested,
@@ -28,6 +29,7 @@ from src.utils.analytics_emf import (
 
 # Load API key from environment for authentication
 IRIS_API_KEY = os.environ.get("IRIS_API_KEY")
+IRIS_ADMIN_API_KEY = os.environ.get("IRIS_ADMIN_API_KEY")
 OPENAI_API_KEY = os.environ.get("OPENAI_API_KEY")
 
 
@@ -75,6 +77,39 @@ def require_api_key(f):
     return decorated_function
 
 
+def require_admin_key(f):
+    """
+    Middleware decorator guarding admin endpoints with IRIS_ADMIN_API_KEY.
+
+    The admin key is separate from IRIS_API_KEY so regular clients cannot
+    flush the cache. If IRIS_ADMIN_API_KEY is not set, admin endpoints are
+    only reachable in development mode (IRIS_API_KEY also unset).
+
+    Returns:
+        401 Unauthorized if the admin key is missing or invalid
+        403 Forbidden if admin endpoints are disabled
+    """
+
+    @wraps(f)
+    def decorated_function(*args, **kwargs):
+        if not IRIS_ADMIN_API_KEY:
+            if not IRIS_API_KEY:
+                return f(*args, **kwargs)
+            return jsonify({"error": "Admin endpoints are disabled"}), 403
+
+        provided_key = request.headers.get("x-admin-api-key")
+
+        if not provided_key:
+            return jsonify({"error": "Missing x-admin-api-key header"}), 401
+
+        if provided_key != IRIS_ADMIN_API_KEY:
+            return jsonify({"error": "Invalid admin API key"}), 401
+
+        return f(*args, **kwargs)
+
+    return decorated_function
+
+
 @iris_bp.route("/analyze", methods=["POST"])
 @require_api_key
 async def analyze():
@@ -84,6 +119,8 @@ async def analyze():
     {
       "filename": "example.ts",
       "source_code": "..." or "lines": [{"line": 1, "text": "..."}],
+      "source_code_base64": "...",  // alternative to source_code for
+      raw bytes that are awkward to escape as a JSON string
       "language": "typescript"  // optional, defaults
       to "javascript"
     }
@@ -106,8 +143,33 @@ async def analyze():
 
     # Handle both source_code and lines format
     source_code = data.get("source_code")
+    source_code_base64 = data.get("source_code_base64")
     # Validation
 
+    if source_code_base64 is not None:
+        if source_code is not None:
+            return (
+                jsonify(
+                    {
+                        "success": False,
+                        "error": "Provide only one of source_code or source_code_base64",
+                    }
+                ),
+                400,
+            )
+        try:
+            source_code = decode_source_base64(source_code_base64)
+        except ValueError as exc:
+            return (
+                jsonify(
+                    {
+                        "success": False,
+                        "error": f"Invalid source_code_base64: {exc}",
+                    }
+                ),
+                400,
+            )
+
     if not filename:
         return (
             jsonify({"success": False, "error": "Missing required field: filename"}),
@@ -133,6 +195,27 @@ async def analyze():
             ),
             400,
         )
+    if not isinstance(source_code, str):
+        return (
+            jsonify(
+                {
+                    "success": False,
+                    "error": "Field source_code must be a string",
+                }
+            ),
+            400,
+        )
+    if looks_binary(source_code):
+        return (
+            jsonify(
+                {
+                    "success": False,
+                    "error": "Binary input is not supported",
+                    "error_code": "binary_input",
+                }
+            ),
+            400,
+        )
     if _iris_agent is None:
         return (
             jsonify(
@@ -269,3 +352,94 @@ def health():
         ),
         200,
     )
+
+
+def _get_analysis_cache():
+    """Return the agent's analysis cache, or None if caching is unavailable."""
+    if _iris_agent is None:
+        return None
+    return getattr(_iris_agent, "analysis_cache", None)
+
+
+@iris_bp.route("/admin/cache", methods=["GET"])
+@require_admin_key
+def admin_cache_stats():
+    """Return cache statistics from the worker that served this request.
+
+    Disk counts (disk_entries, per-language disk_entries) are shared by all
+    Gunicorn workers. memory_entries, top_keys and the local hit rate are
+    per worker, and the worker is whichever one Gunicorn picked; worker_pid
+    identifies it.
+    """
+    if _iris_agent is None:
+        return jsonify({"success": False, "error": "Cache is unavailable"}), 503
+
+    stats = _iris_agent.get_cache_stats()
+    if "error" in stats:
+        return jsonify({"success": False, "error": stats["error"]}), 503
+
+    return (
+        jsonify({"success": True, "worker_pid": os.getpid(), "cache": stats}),
+        200,
+    )
+
+
+@iris_bp.route("/admin/cache", methods=["DELETE"])
+@require_admin_key
+def admin_cache_evict():
+    """Evict cache entries by language (?language=python) or flush all (?all=true).
+
+    A bare DELETE is rejected so a mistyped parameter cannot wipe the cache.
+    """
+    cache = _get_analysis_cache()
+    if cache is None:
+        return jsonify({"success": False, "error": "Cache is unavailable"}), 503
+
+    language = request.args.get("language")
+    flush_all = request.args.get("all") == "true"
+
+    if language and flush_all:
+        return (
+            jsonify(
+                {
+                    "success": False,
+                    "error": "Provide only one of ?language=<language> or ?all=true",
+                }
+            ),
+            400,
+        )
+
+    if language:
+        removed = cache.evict_language(language)
+        return (
+            jsonify({"success": True, "language": language, "evicted": removed}),
+            200,
+        )
+
+    if flush_all:
+        cache.clear()
+        return jsonify({"success": True, "flushed": True}), 200
+
+    return (
+        jsonify(
+            {
+                "success": False,
+                "error": "Specify ?language=<language> or ?all=true",
+            }
+        ),
+        400,
+    )
+
+
+@iris_bp.route("/admin/cache/<file_hash>", methods=["DELETE"])
+@require_admin_key
+def admin_cache_evict_key(file_hash: str):
+    """Evict a single cache entry by its content hash."""
+    cache = _get_analysis_cache()
+    if cache is None:
+        return jsonify({"success": False, "error": "Cache is unavailable"}), 503
+
+    if not cache.evict(file_hash):
+        return jsonify({"success": False, "error": "Cache entry not found"}), 404
+
+    return jsonify({"success": True, "evicted": file_hash}), 200
@@ -0,0 +1,109 @@
+"""Normalization and validation of source code submitted for analysis.
+
+Runs before hashing and prompt construction so that the same file sent
+from different clients (Windows CRLF, BOM-prefixed) analyzes and caches
+identically.
+"""
+
+import base64
+import binascii
+
+UTF8_BOM = "\ufeff"
+
+
+def normalize_source_text(source_code: str) -> str:
+    """Strip a leading BOM and normalize line endings to LF.
+
+    CRLF and lone CR are both converted to LF. Together with the LF-only
+    line numbering in build_single_shot_user_prompt(), this keeps returned
+    1-based line ranges on the same lines editors display for the original
+    text.
+
+    Args:
+        source_code: Source code as submitted by the client.
+
+    Returns:
+        Source code without BOM and with LF line endings only.
+
+    Examples:
+        >>> normalize_source_text("\\ufeffimport os\\r\\nx = 1\\r\\n")
+        'import os\\nx = 1\\n'
+    """
+    if source_code.startswith(UTF8_BOM):
+        source_code = source_code[len(UTF8_BOM):]
+
+    return source_code.replace("\r\n", "\n").replace("\r", "\n")
+
+
+# Sniff window and threshold for binary detection (git also sniffs 8000 bytes)
+BINARY_SNIFF_CHARS = 8000
+BINARY_SUSPICIOUS_RATIO = 0.1
+
+_ALLOWED_CONTROL_CHARS = {"\t", "\n", "\r", "\f", "\v"}
+
+
+def looks_binary(source_code: str) -> bool:
+    """Heuristically detect binary content posted as source code.
+
+    Any NUL character marks the input as binary. Otherwise the first
+    BINARY_SNIFF_CHARS characters are checked for the share of Unicode
+    replacement characters (left behind by invalid UTF-8 decoding) and
+    non-whitespace control characters.
+
+    Args:
+        source_code: Source code as submitted by the client.
+
+    Returns:
+        True if the content is likely binary and should not be analyzed.
+    """
+    if "\x00" in source_code:
+        return True
+
+    sample = source_code[:BINARY_SNIFF_CHARS]
+    if not sample:
+        return False
+
+    suspicious = sum(
+        1
+        for char in sample
+        if char == "\ufffd" or (char < " " and char not in _ALLOWED_CONTROL_CHARS)
+    )
+    return suspicious / len(sample) > BINARY_SUSPICIOUS_RATIO
+
+
+_ASCII_WHITESPACE_TABLE = str.maketrans("", "", " \t\n\r\f\v")
+
+
+def decode_source_base64(encoded: str) -> str:
+    """Decode base64-encoded source code sent instead of a JSON string.
+
+    ASCII whitespace is ignored, so line-wrapped output from the base64
+    CLI or MIME encoders is accepted. The decoded bytes must be valid UTF-8;
+    they are never lossily replaced, so the analyzed text is exactly what
+    the client sent.
+
+    Args:
+        encoded: Standard base64 (RFC 4648) encoding of the file bytes,
+            optionally wrapped across lines.
+
+    Returns:
+        Decoded source code.
+
+    Raises:
+        ValueError: If the value is not a string, not valid base64, or does
+            not decode to valid UTF-8.
+    """
+    if not isinstance(encoded, str):
+        raise ValueError("expected a base64 string")
+
+    unwrapped = encoded.translate(_ASCII_WHITESPACE_TABLE)
+
+    try:
+        raw = base64.b64decode(unwrapped, validate=True)
+    except binascii.Error as e:
+        raise ValueError(f"malformed base64 ({e})") from e
+
+    try:
+        return raw.decode("utf-8")
+    except UnicodeDecodeError as e:
+        raise ValueError("not valid UTF-8") from e
@@ -0,0 +1,162 @@
+"""Unit tests for AnalysisCache eviction and per-language stats.
+
+Covers the operations backing the admin cache endpoints: evicting a
+single key, evicting every entry of a language, and flushing all, including
+invalidation seen by a second worker sharing the same disk cache.
+"""
+
+import asyncio
+import json
+from datetime import datetime
+
+from src.analysis_cache import AnalysisCache, AnalysisResult
+
+
+def _result(language: str) -> AnalysisResult:
+    return AnalysisResult(
+        file_intent=f"{language} module",
+        responsibility_blocks=[],
+        language=language,
+    )
+
+
+def _populated_cache(tmp_path) -> AnalysisCache:
+    cache = AnalysisCache(disk_cache_dir=tmp_path)
+    asyncio.run(cache.set("py1", _result("python")))
+    asyncio.run(cache.set("py2", _result("python")))
+    asyncio.run(cache.set("ts1", _result("typescript")))
+    return cache
+
+
+def _write_legacy_entry(tmp_path, file_hash: str) -> None:
+    """Write a disk entry in the pre-language format (no 'language' key)."""
+    data = {
+        "file_intent": "legacy module",
+        "responsibility_blocks": [],
+        "analyzed_at": datetime.now().timestamp(),
+    }
+    (tmp_path / f"{file_hash}.json").write_text(json.dumps(data))
+
+
+class TestEvictLanguage:
+
+    def test_should_remove_only_matching_entries_when_evicting_language(
+        self, tmp_path
+    ):
+        cache = _populated_cache(tmp_path)
+
+        removed = cache.evict_language("python")
+
+        assert removed == 2
+        assert asyncio.run(cache.get("py1")) is None
+        assert asyncio.run(cache.get("py2")) is None
+        assert asyncio.run(cache.get("ts1"))["language"] == "typescript"
+
+    def test_should_remove_disk_only_entries_when_evicting_language(self, tmp_path):
+        _populated_cache(tmp_path)
+        fresh_cache = AnalysisCache(disk_cache_dir=tmp_path)
+
+        assert fresh_cache.evict_language("python") == 2
+        assert not (tmp_path / "py1.json").exists()
+        assert (tmp_path / "ts1.json").exists()
+
+    def test_should_remove_legacy_entries_when_evicting_unknown(self, tmp_path):
+        cache = _populated_cache(tmp_path)
+        _write_legacy_entry(tmp_path, "legacy1")
+        asyncio.run(cache.get("legacy1"))  # promote to memory
+
+        assert cache.get_cache_stats()["languages"]["unknown"]["disk_entries"] == 1
+        assert cache.evict_language("unknown") == 1
+        assert asyncio.run(cache.get("legacy1")) is None
+        assert asyncio.run(cache.get("py1")) is not None
+
+
+class TestMultiWorkerInvalidation:
+    """Two caches on one directory stand in for two Gunicorn workers."""
+
+    def test_should_miss_in_other_worker_when_key_evicted(self, tmp_path):
+        worker_a = _populated_cache(tmp_path)
+        worker_b = AnalysisCache(disk_cache_dir=tmp_path)
+        assert asyncio.run(worker_b.get("py1")) is not None  # now in B's memory
+
+        worker_a.evict("py1")
+
+        assert asyncio.run(worker_b.get("py1")) is None
+        assert asyncio.run(worker_b.get("ts1")) is not None
+
+    def test_should_miss_in_other_worker_when_language_evicted(self, tmp_path):
+        worker_a = _populated_cache(tmp_path)
+        worker_b = AnalysisCache(disk_cache_dir=tmp_path)
+        asyncio.run(worker_b.get("py2"))
+
+        worker_a.evict_language("python")
+
+        assert asyncio.run(worker_b.get("py2")) is None
+
+    def test_should_miss_in_other_worker_when_flushed(self, tmp_path):
+        worker_a = _populated_cache(tmp_path)
+        worker_b = AnalysisCache(disk_cache_dir=tmp_path)
+        asyncio.run(worker_b.get("ts1"))
+
+        worker_a.clear()
+
+        assert asyncio.run(worker_b.get("ts1")) is None
+        assert worker_b.get_cache_stats()["memory_entries"] == 0
+
+
+class TestDiskWriteFailure:
+
+    def test_should_serve_memory_hit_when_disk_write_fails(self, tmp_path):
+        cache = AnalysisCache(disk_cache_dir=tmp_path)
+        cache._write_to_disk = lambda file_hash, result: False
+
+        asyncio.run(cache.set("mem1", _result("python")))
+
+        assert not (tmp_path / "mem1.json").exists()
+        assert asyncio.run(cache.get("mem1"))["file_intent"] == "python module"
+        assert asyncio.run(cache.get("mem1")) is not None
+
+    def test_should_serve_memory_hit_when_cache_dir_removed(self, tmp_path):
+        cache_dir = tmp_path / "cache"
+        cache = AnalysisCache(disk_cache_dir=cache_dir)
+        cache_dir.rmdir()
+
+        assert cache._write_to_disk("h", _result("python")) is False
+
+        asyncio.run(cache.set("h", _result("python")))
+        assert asyncio.run(cache.get("h")) is not None
+
+
+class TestEvictKey:
+
+    def test_should_remove_entry_when_key_exists(self, tmp_path):
+        cache = _populated_cache(tmp_path)
+
+        assert cache.evict("ts1") is True
+        assert asyncio.run(cache.get("ts1")) is None
+        assert asyncio.run(cache.get("py1")) is not None
+
+    def test_should_return_false_when_key_missing(self, tmp_path):
+        cache = _populated_cache(tmp_path)
+        assert cache.evict("missing") is False
+
+
+class TestCacheStats:
+
+    def test_should_group_entries_by_language_when_getting_stats(self, tmp_path):
+        cache = _populated_cache(tmp_path)
+
+        stats = cache.get_cache_stats()
+
+        assert stats["disk_entries"] == 3
+        assert stats["languages"]["python"]["disk_entries"] == 2
+        assert stats["languages"]["python"]["top_keys"] == ["py2", "py1"]
+        assert stats["languages"]["typescript"]["top_keys"] == ["ts1"]
+
+    def test_should_empty_cache_when_cleared(self, tmp_path):
+        cache = _populated_cache(tmp_path)
+
+        cache.clear()
+
+        assert cache.get_cache_stats()["disk_entries"] == 0
+        assert cache.get_cache_stats()["languages"] == {}
@@ -59,3 +59,19 @@ class TestBuildSingleShotUserPrompt:
         )
         assert "   1|x = 1" in result
         assert "   2|" not in result
+
+    def test_should_not_split_lines_when_form_feed_or_line_separator(self):
+        source = "a = 1\x0c\nb = '\u2028'\nc = 3"
+        result = build_single_shot_user_prompt(
+            "test.py", "python", source
+        )
+        assert "   2|b = '\u2028'" in result
+        assert "   3|c = 3" in result
+        assert "   4|" not in result
+
+    def test_should_not_number_extra_line_when_trailing_newline(self):
+        result = build_single_shot_user_prompt(
+            "test.py", "python", "x = 1\n"
+        )
+        assert "   1|x = 1" in result
+        assert "   2|" not in result
@@ -0,0 +1,227 @@
+"""Flask test_client tests for IRIS API route guards.
+
+IrisAgent is replaced with a stub backed by a real AnalysisCache in a
+temporary directory, so no LLM calls are made.
+"""
+
+import asyncio
+import base64
+
+import pytest
+from flask import Flask
+
+from src import routes
+from src.analysis_cache import AnalysisCache, AnalysisResult
+
+CLIENT_KEY = "client-key"
+ADMIN_KEY = "admin-key"
+ADMIN_HEADERS = {"x-admin-api-key": ADMIN_KEY}
+
+
+class _StubAgent:
+    """Minimal IrisAgent stand-in exposing only the cache surface."""
+
+    def __init__(self, analysis_cache: AnalysisCache):
+        self.analysis_cache = analysis_cache
+
+    def get_cache_stats(self):
+        return {
+            "cache_monitor": None,
+            "analysis_cache": self.analysis_cache.get_cache_stats(),
+        }
+
+
+@pytest.fixture
+def cache(tmp_path):
+    cache = AnalysisCache(disk_cache_dir=tmp_path)
+    for file_hash, language in [
+        ("py1", "python"),
+        ("py2", "python"),
+        ("ts1", "typescript"),
+    ]:
+        result = AnalysisResult(
+            file_intent=f"{language} module",
+            responsibility_blocks=[],
+            language=language,
+        )
+        asyncio.run(cache.set(file_hash, result))
+    return cache
+
+
+@pytest.fixture
+def client(monkeypatch, cache):
+    monkeypatch.setattr(routes, "_iris_agent", _StubAgent(cache))
+    monkeypatch.setattr(routes, "IRIS_API_KEY", CLIENT_KEY)
+    monkeypatch.setattr(routes, "IRIS_ADMIN_API_KEY", ADMIN_KEY)
+
+    app = Flask(__name__)
+    app.register_blueprint(routes.iris_bp)
+    return app.test_client()
+
+
+class TestAdminAuth:
+
+    def test_should_return_403_when_admin_key_unset_in_production(
+        self, client, monkeypatch
+    ):
+        monkeypatch.setattr(routes, "IRIS_ADMIN_API_KEY", None)
+
+        response = client.get("/api/iris/admin/cache")
+
+        assert response.status_code == 403
+        assert response.get_json()["error"] == "Admin endpoints are disabled"
+
+    def test_should_return_401_when_admin_header_missing(self, client):
+        response = client.get("/api/iris/admin/cache")
+
+        assert response.status_code == 401
+
+    def test_should_return_401_when_admin_key_wrong(self, client):
+        response = client.get(
+            "/api/iris/admin/cache", headers={"x-admin-api-key": "wrong"}
+        )
+
+        assert response.status_code == 401
+
+    def test_should_return_401_when_client_key_used_for_admin(self, client):
+        response = client.delete(
+            "/api/iris/admin/cache?all=true", headers={"x-api-key": CLIENT_KEY}
+        )
+
+        assert response.status_code == 401
+
+    def test_should_return_stats_when_admin_key_valid(self, client):
+        response = client.get("/api/iris/admin/cache", headers=ADMIN_HEADERS)
+
+        body = response.get_json()
+        assert response.status_code == 200
+        assert "worker_pid" in body
+        assert body["cache"]["analysis_cache"]["disk_entries"] == 3
+
+
+class TestAdminCacheEvict:
+
+    def test_should_return_400_and_keep_entries_when_bare_delete(
+        self, client, cache
+    ):
+        response = client.delete("/api/iris/admin/cache", headers=ADMIN_HEADERS)
+
+        assert response.status_code == 400
+        assert cache.get_cache_stats()["disk_entries"] == 3
+
+    def test_should_return_400_when_parameter_mistyped(self, client, cache):
+        response = client.delete(
+            "/api/iris/admin/cache?lang=python", headers=ADMIN_HEADERS
+        )
+
+        assert response.status_code == 400
+        assert cache.get_cache_stats()["disk_entries"] == 3
+
+    def test_should_return_400_when_language_empty(self, client, cache):
+        response = client.delete(
+            "/api/iris/admin/cache?language=", headers=ADMIN_HEADERS
+        )
+
+        assert response.status_code == 400
+        assert cache.get_cache_stats()["disk_entries"] == 3
+
+    def test_should_return_400_when_language_and_all_both_given(
+        self, client, cache
+    ):
+        response = client.delete(
+            "/api/iris/admin/cache?language=python&all=true", headers=ADMIN_HEADERS
+        )
+
+        assert response.status_code == 400
+        assert cache.get_cache_stats()["disk_entries"] == 3
+
+    def test_should_evict_only_language_when_language_given(self, client, cache):
+        response = client.delete(
+            "/api/iris/admin/cache?language=python", headers=ADMIN_HEADERS
+        )
+
+        assert response.status_code == 200
+        assert response.get_json()["evicted"] == 2
+        assert cache.get_cache_stats()["disk_entries"] == 1
+
+    def test_should_flush_all_when_all_true(self, client, cache):
+        response = client.delete(
+            "/api/iris/admin/cache?all=true", headers=ADMIN_HEADERS
+        )
+
+        assert response.status_code == 200
+        assert cache.get_cache_stats()["disk_entries"] == 0
+
+
+class TestAdminCacheEvictKey:
+
+    def test_should_return_404_when_hash_unknown(self, client, cache):
+        response = client.delete(
+            "/api/iris/admin/cache/missing", headers=ADMIN_HEADERS
+        )
+
+        assert response.status_code == 404
+        assert cache.get_cache_stats()["disk_entries"] == 3
+
+    def test_should_evict_entry_when_hash_known(self, client, cache):
+        response = client.delete("/api/iris/admin/cache/ts1", headers=ADMIN_HEADERS)
+
+        assert response.status_code == 200
+        assert asyncio.run(cache.get("ts1")) is None
+        assert asyncio.run(cache.get("py1")) is not None
+
+
+class TestAnalyzeValidation:
+
+    @pytest.mark.parametrize("source_code", [123, [{"line": 1, "text": "x"}]])
+    def test_should_return_400_json_when_source_code_not_string(
+        self, client, source_code
+    ):
+        response = client.post(
+            "/api/iris/analyze",
+            json={
+                "filename": "a.py",
+                "language": "python",
+                "source_code": source_code,
+            },
+            headers={"x-api-key": CLIENT_KEY},
+        )
+
+        assert response.status_code == 400
+        assert response.get_json() == {
+            "success": False,
+            "error": "Field source_code must be a string",
+        }
+
+    def test_should_return_400_json_when_binary_input(self, client):
+        response = client.post(
+            "/api/iris/analyze",
+            json={
+                "filename": "a.py",
+                "language": "python",
+                "source_code": "GIF89a\x00\x01",
+            },
+            headers={"x-api-key": CLIENT_KEY},
+        )
+
+        assert response.status_code == 400
+        assert response.get_json()["error_code"] == "binary_input"
+
+    def test_should_return_400_when_base64_not_utf8(self, client):
+        encoded = base64.b64encode("# caf\xe9\n".encode("latin-1")).decode("ascii")
+
+        response = client.post(
+            "/api/iris/analyze",
+            json={
+                "filename": "a.py",
+                "language": "python",
+                "source_code_base64": encoded,
+            },
+            headers={"x-api-key": CLIENT_KEY},
+        )
+
+        assert response.status_code == 400
+        assert response.get_json() == {
+            "success": False,
+            "error": "Invalid source_code_base64: not valid UTF-8",
+        }
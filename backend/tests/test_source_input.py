@@ -0,0 +1,111 @@
+"""Unit tests for source normalization and validation before analysis."""
+
+import base64
+
+import pytest
+
+from src.analysis_cache import compute_file_hash
+from src.utils.source_input import (
+    decode_source_base64,
+    looks_binary,
+    normalize_source_text,
+)
+from tests.conftest import SAMPLES_DIR
+
+
+def _read_sample(relative_path: str) -> str:
+    return (SAMPLES_DIR / relative_path).read_text(encoding="utf-8")
+
+
+class TestNormalizeSourceText:
+
+    def test_should_match_lf_version_when_crlf_with_bom(self):
+        lf_source = _read_sample("python/class_with_methods.py")
+        crlf_source = "\ufeff" + lf_source.replace("\n", "\r\n")
+
+        normalized = normalize_source_text(crlf_source)
+
+        assert normalized == lf_source
+        assert compute_file_hash(normalized) == compute_file_hash(lf_source)
+
+    def test_should_preserve_line_numbers_when_crlf_with_bom(self):
+        lf_source = _read_sample("python/class_with_methods.py")
+        crlf_source = "\ufeff" + lf_source.replace("\n", "\r\n")
+
+        normalized_lines = normalize_source_text(crlf_source).splitlines()
+
+        assert normalized_lines == lf_source.splitlines()
+
+    def test_should_convert_lone_cr_when_classic_mac_endings(self):
+        assert normalize_source_text("a = 1\rb = 2\r") == "a = 1\nb = 2\n"
+
+    def test_should_only_strip_leading_bom_when_bom_appears_later(self):
+        assert normalize_source_text('s = "\ufeff"') == 's = "\ufeff"'
+
+    def test_should_passthrough_when_already_normalized(self):
+        source = "import os\n\nprint(os.getcwd())\n"
+        assert normalize_source_text(source) == source
+
+
+class TestLooksBinary:
+
+    def test_should_detect_binary_when_null_bytes_present(self):
+        assert looks_binary("GIF89a\x00\x01\x02") is True
+
+    def test_should_detect_binary_when_mostly_replacement_chars(self):
+        blob = "\ufffd\x07\ufffd\x1bPK\x03\x04" * 50
+        assert looks_binary(blob) is True
+
+    def test_should_accept_source_when_valid_sample(self):
+        assert looks_binary(_read_sample("python/data_pipeline.py")) is False
+
+    def test_should_accept_source_when_occasional_control_char(self):
+        source = "ESC = '\x1b'\n" + "x = 1\n" * 20
+        assert looks_binary(source) is False
+
+    def test_should_accept_source_when_empty(self):
+        assert looks_binary("") is False
+
+
+class TestDecodeSourceBase64:
+
+    def test_should_match_plain_text_when_sample_base64_encoded(self):
+        source = _read_sample("typescript/service_class.ts")
+        encoded = base64.b64encode(source.encode("utf-8")).decode("ascii")
+
+        assert decode_source_base64(encoded) == source
+
+    def test_should_preserve_exact_whitespace_when_decoding(self):
+        source = "a\t=\x0c1\r\n\u00a0b = 2\n\n"
+        encoded = base64.b64encode(source.encode("utf-8")).decode("ascii")
+
+        assert decode_source_base64(encoded) == source
+
+    def test_should_decode_when_base64_line_wrapped(self):
+        source = _read_sample("python/data_pipeline.py")
+        wrapped = base64.encodebytes(source.encode("utf-8")).decode("ascii")
+        assert "\n" in wrapped
+
+        assert decode_source_base64(wrapped) == source
+        assert decode_source_base64("YWJj\r\nZGVm\n") == "abcdef"
+
+    def test_should_raise_when_malformed_base64(self):
+        with pytest.raises(ValueError):
+            decode_source_base64("not*base64!")
+
+    def test_should_raise_when_not_a_string(self):
+        with pytest.raises(ValueError):
+            decode_source_base64(12345)
+
+    def test_should_raise_when_decoded_bytes_invalid_utf8(self):
+        encoded = base64.b64encode(bytes(range(128, 256)) * 4).decode("ascii")
+
+        with pytest.raises(ValueError, match="not valid UTF-8"):
+            decode_source_base64(encoded)
+
+    def test_should_raise_when_latin1_accents_in_mostly_ascii_file(self):
+        source = "# caf\xe9\n" + "x = 1\n" * 50
+        encoded = base64.b64encode(source.encode("latin-1")).decode("ascii")
+
+        with pytest.raises(ValueError, match="not valid UTF-8"):
+            decode_source_base64(encoded)